@@ -6,13 +6,22 @@ import (
 	"image/png"
 	"os"
 
+	"gotracing/pkg/pathtracer"
+	"gotracing/pkg/postprocess"
 	"gotracing/pkg/raytracer"
 	"gotracing/pkg/scene"
 )
 
+// renderer is implemented by both the Whitted-style raytracer and the
+// Monte Carlo path tracer so main can pick between them on a single line.
+type renderer interface {
+	Render() (*postprocess.Framebuffer, error)
+}
+
 func main() {
 	sceneFile := flag.String("scene", "", "Path to the scene file")
 	outputFile := flag.String("output", "output.png", "Path to the output image file")
+	mode := flag.String("mode", "whitted", "Rendering mode: \"whitted\" or \"pathtrace\"")
 
 	flag.Parse()
 
@@ -26,20 +35,38 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load scene file: %v\n", err)
 		os.Exit(1)
 	}
+	s.Build()
 
 	width := 800
 	height := 600
 	maxDepth := 5
 	samples := 100
 
-	r := raytracer.NewRaytracer(s, width, height, maxDepth, samples)
+	var r renderer
+	switch *mode {
+	case "pathtrace":
+		r = pathtracer.NewPathtracer(s, width, height, maxDepth, samples)
+	case "whitted":
+		r = raytracer.NewRaytracer(s, width, height, maxDepth, samples)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown -mode %q: must be \"whitted\" or \"pathtrace\"\n", *mode)
+		os.Exit(1)
+	}
 
-	img, err := r.Render()
+	fb, err := r.Render()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Rendering failed: %v\n", err)
 		os.Exit(1)
 	}
 
+	pipeline := postprocess.NewPipeline(
+		postprocess.Bloom{Threshold: 1.0, Downsample: 4, KernelRadius: 2, Intensity: 0.25},
+		postprocess.Exposure{Scale: 1.0},
+		postprocess.Tonemap{Operator: postprocess.ACESApprox},
+		postprocess.Gamma{Gamma: 2.2},
+	)
+	img := pipeline.Process(fb)
+
 	file, err := os.Create(*outputFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to open output file: %v\n", err)
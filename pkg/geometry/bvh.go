@@ -0,0 +1,257 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// bvhLeafSize is the primitive count at or below which a node becomes a
+	// leaf without evaluating a split. A larger node also becomes a leaf when
+	// no SAH split beats the leaf cost, so a leaf can hold more than this.
+	bvhLeafSize = 4
+	// bvhBuckets is how many SAH buckets each axis is binned into when
+	// searching for the cheapest split.
+	bvhBuckets = 12
+	// bvhTraversalCost and bvhIntersectCost are the relative costs fed into
+	// the SAH cost function; only their ratio matters.
+	bvhTraversalCost = 1.0
+	bvhIntersectCost = 1.0
+)
+
+// BVHNode is a node of a binary bounding volume hierarchy. Interior nodes
+// have Left and Right set and no Primitives; leaves have Primitives and no
+// children.
+type BVHNode struct {
+	Bounds      AABB
+	Left, Right *BVHNode
+	Primitives  []Object
+	Axis        int // split axis, used to order traversal by ray direction
+}
+
+func (n *BVHNode) isLeaf() bool {
+	return n.Left == nil && n.Right == nil
+}
+
+// BVH is a bounding volume hierarchy built once over a scene's objects and
+// traversed for every ray cast afterwards.
+type BVH struct {
+	root *BVHNode
+}
+
+type bvhPrimitive struct {
+	object   Object
+	bounds   AABB
+	centroid *Vector
+}
+
+// BuildBVH constructs a BVH over objects using a surface-area heuristic: at
+// each node, every axis is binned into bvhBuckets buckets by primitive
+// centroid, the cost of splitting at every bucket boundary is evaluated, and
+// the cheapest split is taken — or, if no split beats the cost of a single
+// leaf, a leaf is made instead.
+func BuildBVH(objects []Object) *BVH {
+	prims := make([]bvhPrimitive, len(objects))
+	for i, o := range objects {
+		b := o.BoundingBox()
+		prims[i] = bvhPrimitive{object: o, bounds: b, centroid: b.Centroid()}
+	}
+	return &BVH{root: buildBVHNode(prims)}
+}
+
+func buildBVHNode(prims []bvhPrimitive) *BVHNode {
+	bounds := prims[0].bounds
+	centroidBounds := AABB{prims[0].centroid, prims[0].centroid}
+	for _, p := range prims[1:] {
+		bounds = bounds.Union(p.bounds)
+		centroidBounds = centroidBounds.Union(AABB{p.centroid, p.centroid})
+	}
+
+	if len(prims) <= bvhLeafSize {
+		return leafNode(prims, bounds)
+	}
+
+	axis, split := bestSAHSplit(prims, bounds, centroidBounds)
+	if axis < 0 {
+		return leafNode(prims, bounds)
+	}
+
+	return &BVHNode{
+		Bounds: bounds,
+		Axis:   axis,
+		Left:   buildBVHNode(prims[:split]),
+		Right:  buildBVHNode(prims[split:]),
+	}
+}
+
+func leafNode(prims []bvhPrimitive, bounds AABB) *BVHNode {
+	objects := make([]Object, len(prims))
+	for i, p := range prims {
+		objects[i] = p.object
+	}
+	return &BVHNode{Bounds: bounds, Primitives: objects}
+}
+
+func axisComponent(v *Vector, axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	default:
+		return v.Z
+	}
+}
+
+func sortByAxis(prims []bvhPrimitive, axis int) {
+	sort.Slice(prims, func(i, j int) bool {
+		return axisComponent(prims[i].centroid, axis) < axisComponent(prims[j].centroid, axis)
+	})
+}
+
+type bvhBucket struct {
+	count  int
+	bounds AABB
+	has    bool
+}
+
+func (b *bvhBucket) add(bounds AABB) {
+	if !b.has {
+		b.bounds = bounds
+		b.has = true
+	} else {
+		b.bounds = b.bounds.Union(bounds)
+	}
+	b.count++
+}
+
+// bestSAHSplit evaluates the SAH cost of every bucket boundary on every
+// axis, sorts prims along the cheapest axis found, and returns that axis
+// together with the primitive count to its left. It returns axis -1 if
+// splitting never beats the cost of a single leaf.
+func bestSAHSplit(prims []bvhPrimitive, bounds, centroidBounds AABB) (int, int) {
+	parentArea := bounds.SurfaceArea()
+	if parentArea <= 0 {
+		return -1, 0
+	}
+
+	bestCost := float64(len(prims)) * bvhIntersectCost
+	bestAxis := -1
+	bestLeftCount := 0
+
+	for axis := 0; axis < 3; axis++ {
+		extentMin := axisComponent(centroidBounds.Min, axis)
+		extentMax := axisComponent(centroidBounds.Max, axis)
+		if extentMax-extentMin < 1e-9 {
+			continue
+		}
+
+		var buckets [bvhBuckets]bvhBucket
+		bucketOf := func(c float64) int {
+			idx := int(float64(bvhBuckets) * (c - extentMin) / (extentMax - extentMin))
+			if idx < 0 {
+				idx = 0
+			}
+			if idx >= bvhBuckets {
+				idx = bvhBuckets - 1
+			}
+			return idx
+		}
+
+		for _, p := range prims {
+			buckets[bucketOf(axisComponent(p.centroid, axis))].add(p.bounds)
+		}
+
+		for split := 1; split < bvhBuckets; split++ {
+			var leftCount, rightCount int
+			var leftBounds, rightBounds AABB
+			leftSet, rightSet := false, false
+
+			for i := 0; i < split; i++ {
+				if buckets[i].count == 0 {
+					continue
+				}
+				leftCount += buckets[i].count
+				if !leftSet {
+					leftBounds, leftSet = buckets[i].bounds, true
+				} else {
+					leftBounds = leftBounds.Union(buckets[i].bounds)
+				}
+			}
+			for i := split; i < bvhBuckets; i++ {
+				if buckets[i].count == 0 {
+					continue
+				}
+				rightCount += buckets[i].count
+				if !rightSet {
+					rightBounds, rightSet = buckets[i].bounds, true
+				} else {
+					rightBounds = rightBounds.Union(buckets[i].bounds)
+				}
+			}
+
+			if leftCount == 0 || rightCount == 0 {
+				continue
+			}
+
+			cost := bvhTraversalCost + (float64(leftCount)*leftBounds.SurfaceArea()+
+				float64(rightCount)*rightBounds.SurfaceArea())/parentArea*bvhIntersectCost
+			if cost < bestCost {
+				bestCost = cost
+				bestAxis = axis
+				bestLeftCount = leftCount
+			}
+		}
+	}
+
+	if bestAxis < 0 {
+		return -1, 0
+	}
+
+	// Buckets partition primitives by centroid coordinate ranges, so the
+	// left-bucket count computed above is exactly the split index once
+	// prims is sorted by that same axis.
+	sortByAxis(prims, bestAxis)
+	return bestAxis, bestLeftCount
+}
+
+// Intersect traverses the BVH with an explicit stack, visiting the child
+// nearer the ray origin first (per the split axis and the ray direction's
+// sign) so the running closest-hit distance prunes the far child whenever
+// possible.
+func (bvh *BVH) Intersect(ray *Ray) *Hit {
+	if bvh == nil || bvh.root == nil {
+		return nil
+	}
+
+	var closest *Hit
+	closestT := math.Inf(1)
+
+	stack := []*BVHNode{bvh.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !node.Bounds.Hit(ray, 1e-4, closestT) {
+			continue
+		}
+
+		if node.isLeaf() {
+			for _, object := range node.Primitives {
+				if hit := object.Intersect(ray); hit != nil && hit.T < closestT {
+					closest = hit
+					closestT = hit.T
+				}
+			}
+			continue
+		}
+
+		near, far := node.Left, node.Right
+		if axisComponent(ray.Direction, node.Axis) < 0 {
+			near, far = far, near
+		}
+		stack = append(stack, far, near)
+	}
+
+	return closest
+}
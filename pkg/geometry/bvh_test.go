@@ -0,0 +1,111 @@
+package geometry
+
+import (
+	"testing"
+
+	"gotracing/pkg/material"
+)
+
+// TestAABBHitSlabTest exercises AABB.Hit against a unit box at the origin:
+// a ray straight through the middle hits, one aimed past the box misses, and
+// a ray that exactly grazes a face (tMax == tMin) still counts as a hit, per
+// the strict "<" comparison Mesh.Intersect's face BVH relies on for flat
+// (zero-thickness) bounding boxes.
+func TestAABBHitSlabTest(t *testing.T) {
+	box := AABB{Min: &Vector{X: -1, Y: -1, Z: -1}, Max: &Vector{X: 1, Y: 1, Z: 1}}
+
+	through := &Ray{Origin: &Vector{X: 0, Y: 0, Z: -5}, Direction: &Vector{X: 0, Y: 0, Z: 1}}
+	if !box.Hit(through, 1e-4, 1e30) {
+		t.Errorf("expected a ray through the box center to hit")
+	}
+
+	past := &Ray{Origin: &Vector{X: 5, Y: 0, Z: -5}, Direction: &Vector{X: 0, Y: 0, Z: 1}}
+	if box.Hit(past, 1e-4, 1e30) {
+		t.Errorf("expected a ray past the box's X extent to miss")
+	}
+
+	flat := AABB{Min: &Vector{X: -1, Y: 0, Z: -1}, Max: &Vector{X: 1, Y: 0, Z: 1}}
+	grazing := &Ray{Origin: &Vector{X: 0, Y: 5, Z: 0}, Direction: &Vector{X: 0, Y: -1, Z: 0}}
+	if !flat.Hit(grazing, 1e-4, 1e30) {
+		t.Errorf("expected a ray grazing a zero-thickness box exactly on its plane to hit")
+	}
+}
+
+// TestBVHIntersectMatchesLinearScan builds a BVH over a row of spheres and
+// an axis-aligned ground plane, and checks that every result
+// agrees with a brute-force linear scan over the same objects — including
+// for the plane, whose BoundingBox no longer collapses to a box centered on
+// the origin.
+func TestBVHIntersectMatchesLinearScan(t *testing.T) {
+	var objects []Object
+	for i := 0; i < 20; i++ {
+		objects = append(objects, &Sphere{
+			Center: &Vector{X: float64(i * 3), Y: 1, Z: 0},
+			Radius: 0.5,
+			Mat:    &material.Material{},
+		})
+	}
+	objects = append(objects, &Plane{
+		Point:  &Vector{X: 0, Y: -1, Z: 0},
+		Normal: &Vector{X: 0, Y: 1, Z: 0},
+		Mat:    &material.Material{},
+	})
+
+	bvh := BuildBVH(objects)
+
+	linearScan := func(ray *Ray) *Hit {
+		var closest *Hit
+		for _, o := range objects {
+			if hit := o.Intersect(ray); hit != nil {
+				if closest == nil || hit.T < closest.T {
+					closest = hit
+				}
+			}
+		}
+		return closest
+	}
+
+	for i := 0; i < 20; i++ {
+		ray := &Ray{
+			Origin:    &Vector{X: float64(i * 3), Y: 5, Z: 0},
+			Direction: &Vector{X: 0, Y: -1, Z: 0},
+		}
+		want := linearScan(ray)
+		got := bvh.Intersect(ray)
+		if (want == nil) != (got == nil) {
+			t.Fatalf("ray %d: linear scan hit=%v, BVH hit=%v", i, want != nil, got != nil)
+		}
+		if want != nil && want.T != got.T {
+			t.Fatalf("ray %d: linear scan T=%v, BVH T=%v", i, want.T, got.T)
+		}
+	}
+}
+
+// TestPlaneBoundingBoxReflectsPosition checks that an axis-aligned plane's
+// BoundingBox is bounded tightly (and at the plane's actual coordinate) on
+// the axis its normal points along, rather than being symmetrically
+// infinite on every axis regardless of where the plane sits.
+func TestPlaneBoundingBoxReflectsPosition(t *testing.T) {
+	near := &Plane{Point: &Vector{X: 0, Y: 3, Z: 0}, Normal: &Vector{X: 0, Y: 1, Z: 0}}
+	far := &Plane{Point: &Vector{X: 0, Y: 300, Z: 0}, Normal: &Vector{X: 0, Y: 1, Z: 0}}
+
+	nearCentroid := near.BoundingBox().Centroid()
+	farCentroid := far.BoundingBox().Centroid()
+
+	if nearCentroid.Y != 3 {
+		t.Errorf("expected near plane's centroid Y to be 3, got %v", nearCentroid.Y)
+	}
+	if farCentroid.Y != 300 {
+		t.Errorf("expected far plane's centroid Y to be 300, got %v", farCentroid.Y)
+	}
+	if nearCentroid.Y == farCentroid.Y {
+		t.Errorf("expected two planes at different heights to have different centroids")
+	}
+
+	// X and Z are still unbounded since the plane extends infinitely along
+	// them.
+	box := near.BoundingBox()
+	if box.Min.X != -infiniteExtent || box.Max.X != infiniteExtent {
+		t.Errorf("expected the plane to remain unbounded on X, got min=%v max=%v", box.Min.X, box.Max.X)
+	}
+}
@@ -45,6 +45,17 @@ func (v *Vector) Normalize() *Vector {
 	return &Vector{v.X / len, v.Y / len, v.Z / len}
 }
 
+// Negate returns the vector pointing the opposite direction.
+func (v *Vector) Negate() *Vector {
+	return &Vector{-v.X, -v.Y, -v.Z}
+}
+
+// Reflect returns v reflected about normal n, as used by Whitted-style
+// specular shading.
+func (v *Vector) Reflect(n *Vector) *Vector {
+	return v.Subtract(n.Scale(2 * v.Dot(n)))
+}
+
 // Ray represents a ray.
 type Ray struct {
 	Origin, Direction *Vector
@@ -60,6 +71,87 @@ func (r *Ray) At(t float64) *Vector {
 type Object interface {
 	Intersect(ray *Ray) *Hit
 	Material() *material.Material
+	BoundingBox() AABB
+}
+
+// AABB is an axis-aligned bounding box, used as the bounding volume for the
+// BVH in bvh.go.
+type AABB struct {
+	Min, Max *Vector
+}
+
+// Hit reports whether ray passes through the box within [tMin, tMax], using
+// the standard slab test against each axis in turn. The final comparison is
+// a strict "<" (not "<="), so a box that's degenerate (zero-thickness) along
+// some axis — as a Mesh's faceBVH bounds routinely are for a flat mesh —
+// still counts a ray that exactly grazes that slab as a hit.
+func (b AABB) Hit(ray *Ray, tMin, tMax float64) bool {
+	t0 := (b.Min.X - ray.Origin.X) / ray.Direction.X
+	t1 := (b.Max.X - ray.Origin.X) / ray.Direction.X
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	if t0 > tMin {
+		tMin = t0
+	}
+	if t1 < tMax {
+		tMax = t1
+	}
+	if tMax < tMin {
+		return false
+	}
+
+	t0 = (b.Min.Y - ray.Origin.Y) / ray.Direction.Y
+	t1 = (b.Max.Y - ray.Origin.Y) / ray.Direction.Y
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	if t0 > tMin {
+		tMin = t0
+	}
+	if t1 < tMax {
+		tMax = t1
+	}
+	if tMax < tMin {
+		return false
+	}
+
+	t0 = (b.Min.Z - ray.Origin.Z) / ray.Direction.Z
+	t1 = (b.Max.Z - ray.Origin.Z) / ray.Direction.Z
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	if t0 > tMin {
+		tMin = t0
+	}
+	if t1 < tMax {
+		tMax = t1
+	}
+	if tMax < tMin {
+		return false
+	}
+
+	return true
+}
+
+// Union returns the smallest AABB enclosing both b and o.
+func (b AABB) Union(o AABB) AABB {
+	return AABB{
+		Min: &Vector{math.Min(b.Min.X, o.Min.X), math.Min(b.Min.Y, o.Min.Y), math.Min(b.Min.Z, o.Min.Z)},
+		Max: &Vector{math.Max(b.Max.X, o.Max.X), math.Max(b.Max.Y, o.Max.Y), math.Max(b.Max.Z, o.Max.Z)},
+	}
+}
+
+// Centroid returns the midpoint of the box.
+func (b AABB) Centroid() *Vector {
+	return &Vector{(b.Min.X + b.Max.X) / 2, (b.Min.Y + b.Max.Y) / 2, (b.Min.Z + b.Max.Z) / 2}
+}
+
+// SurfaceArea returns the total surface area of the box, used by the SAH
+// cost function during BVH construction.
+func (b AABB) SurfaceArea() float64 {
+	d := b.Max.Subtract(b.Min)
+	return 2 * (d.X*d.Y + d.Y*d.Z + d.Z*d.X)
 }
 
 // Hit represents a ray-object intersection.
@@ -100,6 +192,11 @@ func (s *Sphere) Material() *material.Material {
 	return s.Mat
 }
 
+func (s *Sphere) BoundingBox() AABB {
+	r := &Vector{s.Radius, s.Radius, s.Radius}
+	return AABB{s.Center.Subtract(r), s.Center.Add(r)}
+}
+
 // Plane represents a plane.
 type Plane struct {
 	Point  *Vector
@@ -124,6 +221,40 @@ func (p *Plane) Material() *material.Material {
 	return p.Mat
 }
 
+// infiniteExtent bounds unbounded primitives (planes, infinite cylinders) so
+// they still fit in the BVH's AABB representation.
+const infiniteExtent = 1e18
+
+// axisAlignedNormalThreshold is how close a normal's component on an axis
+// must be to ±1 before that axis is treated as the plane's orientation axis
+// rather than one it extends infinitely along.
+const axisAlignedNormalThreshold = 0.999
+
+// BoundingBox bounds a plane tightly on the axis its Normal points along (if
+// any), using Point's coordinate on that axis instead of leaving it
+// symmetrically infinite. An axis-aligned plane is by far the common case
+// (a ground or wall plane), and without this the SAH centroid for every
+// plane collapses to the origin regardless of where the plane actually
+// sits, which poisons BVH splits for any scene that places one off-center.
+// A tilted plane still has no finite extent on any axis and stays fully
+// unbounded, same as before.
+func (p *Plane) BoundingBox() AABB {
+	min := &Vector{-infiniteExtent, -infiniteExtent, -infiniteExtent}
+	max := &Vector{infiniteExtent, infiniteExtent, infiniteExtent}
+
+	if math.Abs(p.Normal.X) > axisAlignedNormalThreshold {
+		min.X, max.X = p.Point.X, p.Point.X
+	}
+	if math.Abs(p.Normal.Y) > axisAlignedNormalThreshold {
+		min.Y, max.Y = p.Point.Y, p.Point.Y
+	}
+	if math.Abs(p.Normal.Z) > axisAlignedNormalThreshold {
+		min.Z, max.Z = p.Point.Z, p.Point.Z
+	}
+
+	return AABB{min, max}
+}
+
 // Triangle represents a triangle.
 type Triangle struct {
 	V0, V1, V2 *Vector // vertices
@@ -172,6 +303,20 @@ func (t *Triangle) Material() *material.Material {
 	return t.Mat
 }
 
+func (t *Triangle) BoundingBox() AABB {
+	min := &Vector{
+		math.Min(t.V0.X, math.Min(t.V1.X, t.V2.X)),
+		math.Min(t.V0.Y, math.Min(t.V1.Y, t.V2.Y)),
+		math.Min(t.V0.Z, math.Min(t.V1.Z, t.V2.Z)),
+	}
+	max := &Vector{
+		math.Max(t.V0.X, math.Max(t.V1.X, t.V2.X)),
+		math.Max(t.V0.Y, math.Max(t.V1.Y, t.V2.Y)),
+		math.Max(t.V0.Z, math.Max(t.V1.Z, t.V2.Z)),
+	}
+	return AABB{min, max}
+}
+
 // Cylinder represents an infinite cylinder along the y-axis.
 type Cylinder struct {
 	Center *Vector
@@ -205,6 +350,15 @@ func (c *Cylinder) Material() *material.Material {
 	return c.Mat
 }
 
+// BoundingBox is unbounded along Y since Cylinder is infinite along the
+// y-axis, but finite in X/Z.
+func (c *Cylinder) BoundingBox() AABB {
+	return AABB{
+		&Vector{c.Center.X - c.Radius, -infiniteExtent, c.Center.Z - c.Radius},
+		&Vector{c.Center.X + c.Radius, infiniteExtent, c.Center.Z + c.Radius},
+	}
+}
+
 // Cube represents a cube.
 type Cube struct {
 	Center *Vector
@@ -314,4 +468,9 @@ func (cube *Cube) NormalAt(point *Vector) *Vector {
 	func (cube *Cube) Material() *material.Material {
 		return cube.Mat
 	}
-	
+
+func (cube *Cube) BoundingBox() AABB {
+	half := &Vector{cube.Length / 2, cube.Length / 2, cube.Length / 2}
+	return AABB{cube.Center.Subtract(half), cube.Center.Add(half)}
+}
+
@@ -0,0 +1,170 @@
+package geometry
+
+import (
+	"math"
+
+	"gotracing/pkg/material"
+)
+
+// Vector2 is a 2D texture coordinate, as loaded from an OBJ "vt" directive.
+type Vector2 struct {
+	U, V float64
+}
+
+// MeshFace indexes three vertices (and optionally three normals/UVs) into a
+// Mesh's shared Vertices/Normals/UVs arrays, so triangles in a loaded mesh
+// don't each carry their own copy of their vertex data.
+type MeshFace struct {
+	V0, V1, V2 int
+	N0, N1, N2 int // -1 if the face has no vertex normals
+	T0, T1, T2 int // -1 if the face has no texture coordinates
+}
+
+// Mesh is a triangle mesh backed by shared vertex/normal/UV arrays, as
+// produced by scene.LoadOBJ.
+type Mesh struct {
+	Vertices []*Vector
+	Normals  []*Vector
+	UVs      []Vector2
+	Faces    []MeshFace
+	Mat      *material.Material
+
+	bounds AABB
+	// faceBVH accelerates Intersect over Faces; without it a mesh is one
+	// opaque box in the scene's top-level BVH, and every ray that enters
+	// that box falls back to testing every face in turn.
+	faceBVH *BVH
+}
+
+// NewMesh builds a Mesh, precomputing its bounding box and a second-level
+// BVH over its faces.
+func NewMesh(vertices, normals []*Vector, uvs []Vector2, faces []MeshFace, mat *material.Material) *Mesh {
+	m := &Mesh{Vertices: vertices, Normals: normals, UVs: uvs, Faces: faces, Mat: mat}
+	m.bounds = m.computeBounds()
+	m.faceBVH = m.buildFaceBVH()
+	return m
+}
+
+// buildFaceBVH wraps each face as a geometry.Object so the existing BuildBVH
+// can partition them the same way it partitions top-level scene objects.
+func (m *Mesh) buildFaceBVH() *BVH {
+	if len(m.Faces) == 0 {
+		return nil
+	}
+	prims := make([]Object, len(m.Faces))
+	for i := range m.Faces {
+		prims[i] = &meshFacePrimitive{mesh: m, face: &m.Faces[i]}
+	}
+	return BuildBVH(prims)
+}
+
+// meshFacePrimitive adapts a single Mesh face to the geometry.Object
+// interface purely so BuildBVH can treat faces like any other primitive.
+type meshFacePrimitive struct {
+	mesh *Mesh
+	face *MeshFace
+}
+
+func (f *meshFacePrimitive) Intersect(ray *Ray) *Hit {
+	return f.mesh.intersectFace(ray, f.face)
+}
+
+func (f *meshFacePrimitive) Material() *material.Material {
+	return f.mesh.Mat
+}
+
+func (f *meshFacePrimitive) BoundingBox() AABB {
+	v0 := f.mesh.Vertices[f.face.V0]
+	v1 := f.mesh.Vertices[f.face.V1]
+	v2 := f.mesh.Vertices[f.face.V2]
+	min := &Vector{
+		math.Min(v0.X, math.Min(v1.X, v2.X)),
+		math.Min(v0.Y, math.Min(v1.Y, v2.Y)),
+		math.Min(v0.Z, math.Min(v1.Z, v2.Z)),
+	}
+	max := &Vector{
+		math.Max(v0.X, math.Max(v1.X, v2.X)),
+		math.Max(v0.Y, math.Max(v1.Y, v2.Y)),
+		math.Max(v0.Z, math.Max(v1.Z, v2.Z)),
+	}
+	return AABB{min, max}
+}
+
+func (m *Mesh) computeBounds() AABB {
+	min := &Vector{math.Inf(1), math.Inf(1), math.Inf(1)}
+	max := &Vector{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	for _, v := range m.Vertices {
+		min = &Vector{math.Min(min.X, v.X), math.Min(min.Y, v.Y), math.Min(min.Z, v.Z)}
+		max = &Vector{math.Max(max.X, v.X), math.Max(max.Y, v.Y), math.Max(max.Z, v.Z)}
+	}
+	return AABB{min, max}
+}
+
+func (m *Mesh) Material() *material.Material {
+	return m.Mat
+}
+
+func (m *Mesh) BoundingBox() AABB {
+	return m.bounds
+}
+
+// Intersect traverses the mesh's own second-level BVH over Faces, reading
+// vertices directly out of the shared arrays by index via Moller-Trumbore
+// instead of allocating a Triangle per face.
+func (m *Mesh) Intersect(ray *Ray) *Hit {
+	return m.faceBVH.Intersect(ray)
+}
+
+func (m *Mesh) intersectFace(ray *Ray, face *MeshFace) *Hit {
+	v0 := m.Vertices[face.V0]
+	v1 := m.Vertices[face.V1]
+	v2 := m.Vertices[face.V2]
+
+	edge1 := v1.Subtract(v0)
+	edge2 := v2.Subtract(v0)
+	h := ray.Direction.Cross(edge2)
+	a := edge1.Dot(h)
+
+	if math.Abs(a) < 0.0001 { // this ray is parallel to this triangle
+		return nil
+	}
+
+	f := 1.0 / a
+	s := ray.Origin.Subtract(v0)
+	u := f * s.Dot(h)
+	if u < 0.0 || u > 1.0 {
+		return nil
+	}
+
+	q := s.Cross(edge1)
+	v := f * ray.Direction.Dot(q)
+	if v < 0.0 || u+v > 1.0 {
+		return nil
+	}
+
+	t := f * edge2.Dot(q)
+	if t <= 0.0001 {
+		return nil
+	}
+
+	position := ray.At(t)
+	normal := m.interpolatedNormal(face, edge1, edge2, 1-u-v, u, v)
+
+	return &Hit{position, normal, t, m}
+}
+
+// interpolatedNormal blends the three vertex normals by the barycentric
+// weights (1-u-v, u, v) for smooth shading, falling back to the flat
+// geometric normal when the face has no vertex normals.
+func (m *Mesh) interpolatedNormal(face *MeshFace, edge1, edge2 *Vector, w0, w1, w2 float64) *Vector {
+	if face.N0 < 0 || face.N1 < 0 || face.N2 < 0 {
+		return edge1.Cross(edge2).Normalize()
+	}
+
+	n0 := m.Normals[face.N0]
+	n1 := m.Normals[face.N1]
+	n2 := m.Normals[face.N2]
+
+	normal := n0.Scale(w0).Add(n1.Scale(w1)).Add(n2.Scale(w2))
+	return normal.Normalize()
+}
@@ -0,0 +1,137 @@
+package geometry
+
+import (
+	"testing"
+
+	"gotracing/pkg/material"
+)
+
+// buildGridMesh returns a mesh of n x n unit quads (two triangles each) in
+// the y=0 plane, spanning x,z in [0, n], with flat per-face normals (no "vn"
+// data) so Intersect exercises the face BVH's own box recursion instead of a
+// single leaf.
+func buildGridMesh(n int) *Mesh {
+	var vertices []*Vector
+	index := func(x, z int) int { return x*(n+1) + z }
+	for x := 0; x <= n; x++ {
+		for z := 0; z <= n; z++ {
+			vertices = append(vertices, &Vector{X: float64(x), Y: 0, Z: float64(z)})
+		}
+	}
+
+	var faces []MeshFace
+	for x := 0; x < n; x++ {
+		for z := 0; z < n; z++ {
+			v00 := index(x, z)
+			v10 := index(x+1, z)
+			v01 := index(x, z+1)
+			v11 := index(x+1, z+1)
+			faces = append(faces,
+				MeshFace{V0: v00, V1: v10, V2: v11, N0: -1, N1: -1, N2: -1, T0: -1, T1: -1, T2: -1},
+				MeshFace{V0: v00, V1: v11, V2: v01, N0: -1, N1: -1, N2: -1, T0: -1, T1: -1, T2: -1},
+			)
+		}
+	}
+
+	return NewMesh(vertices, nil, nil, faces, &material.Material{})
+}
+
+// bruteForceIntersect linearly tests every face, bypassing faceBVH entirely,
+// as an oracle to compare the accelerated traversal against.
+func bruteForceIntersect(m *Mesh, ray *Ray) *Hit {
+	var closest *Hit
+	for i := range m.Faces {
+		if hit := m.intersectFace(ray, &m.Faces[i]); hit != nil {
+			if closest == nil || hit.T < closest.T {
+				closest = hit
+			}
+		}
+	}
+	return closest
+}
+
+// TestMeshIntersectMatchesBruteForce checks that routing Mesh.Intersect
+// through the per-mesh face BVH finds the same closest hit a naive linear
+// scan over every face would, across a grid of downward rays, some of which
+// hit the mesh and some of which miss past its edge.
+func TestMeshIntersectMatchesBruteForce(t *testing.T) {
+	mesh := buildGridMesh(8)
+
+	for x := -1; x < 9; x++ {
+		for z := -1; z < 9; z++ {
+			ray := &Ray{
+				Origin:    &Vector{X: float64(x) + 0.5, Y: 5, Z: float64(z) + 0.5},
+				Direction: &Vector{X: 0, Y: -1, Z: 0},
+			}
+
+			want := bruteForceIntersect(mesh, ray)
+			got := mesh.Intersect(ray)
+
+			switch {
+			case want == nil && got == nil:
+				continue
+			case want == nil || got == nil:
+				t.Fatalf("ray at (%d, %d): brute force hit=%v, faceBVH hit=%v", x, z, want != nil, got != nil)
+			case got.T != want.T:
+				t.Fatalf("ray at (%d, %d): brute force T=%v, faceBVH T=%v", x, z, want.T, got.T)
+			}
+		}
+	}
+}
+
+// TestMeshIntersectEmptyMesh ensures a mesh with no faces reports misses
+// instead of panicking, since NewMesh's faceBVH will be nil in that case.
+func TestMeshIntersectEmptyMesh(t *testing.T) {
+	mesh := NewMesh(nil, nil, nil, nil, &material.Material{})
+	ray := &Ray{Origin: &Vector{X: 0, Y: 5, Z: 0}, Direction: &Vector{X: 0, Y: -1, Z: 0}}
+
+	if hit := mesh.Intersect(ray); hit != nil {
+		t.Fatalf("expected no hit against an empty mesh, got %+v", hit)
+	}
+}
+
+// TestInterpolatedNormalBlendsVertexNormals checks that a hit at a face's
+// centroid returns the average of its three vertex normals, rather than
+// falling back to the flat geometric normal.
+func TestInterpolatedNormalBlendsVertexNormals(t *testing.T) {
+	vertices := []*Vector{
+		{X: 0, Y: 0, Z: 0},
+		{X: 1, Y: 0, Z: 0},
+		{X: 0, Y: 1, Z: 0},
+	}
+	normals := []*Vector{
+		{X: 0, Y: 0, Z: 1},
+		{X: 1, Y: 0, Z: 0},
+		{X: 0, Y: 1, Z: 0},
+	}
+	faces := []MeshFace{
+		{V0: 0, V1: 1, V2: 2, N0: 0, N1: 1, N2: 2, T0: -1, T1: -1, T2: -1},
+	}
+	mesh := NewMesh(vertices, normals, nil, faces, &material.Material{})
+
+	// The face's centroid has equal barycentric weights (1/3, 1/3, 1/3), so
+	// the interpolated normal before normalization is the average of the
+	// three vertex normals: (1/3, 1/3, 1/3), which normalizes to (1,1,1)/sqrt(3).
+	ray := &Ray{
+		Origin:    &Vector{X: 1.0 / 3, Y: 1.0 / 3, Z: 5},
+		Direction: &Vector{X: 0, Y: 0, Z: -1},
+	}
+
+	hit := mesh.Intersect(ray)
+	if hit == nil {
+		t.Fatalf("expected a hit at the face centroid")
+	}
+
+	want := 1.0 / 1.7320508075688772 // 1/sqrt(3)
+	const eps = 1e-9
+	if absDiff(hit.Normal.X, want) > eps || absDiff(hit.Normal.Y, want) > eps || absDiff(hit.Normal.Z, want) > eps {
+		t.Fatalf("expected interpolated normal ~(%.6f, %.6f, %.6f), got %+v", want, want, want, hit.Normal)
+	}
+}
+
+func absDiff(a, b float64) float64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
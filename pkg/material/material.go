@@ -1,62 +1,49 @@
 package material
 
-import (
-	"gotracing/pkg/geometry"
-	"gotracing/pkg/scene"
-)
+import "math"
 
 type Color struct {
 	R, G, B float64
 }
 
-type Material struct {
-	Color              Color
-	Reflectivity       float64
-	Transparency       float64
-	RefractiveIndex    float64
-	AmbientCoefficient float64
-	DiffuseCoefficient float64
-	SpecularCoefficient float64
-	Shininess          float64
+// Add returns the component-wise sum of c and o.
+func (c *Color) Add(o *Color) *Color {
+	return &Color{c.R + o.R, c.G + o.G, c.B + o.B}
 }
 
-func (m *Material) ComputeColor(hit *geometry.Hit, s *scene.Scene, ray *geometry.Ray) Color {
-	color := m.Color.Scale(m.AmbientCoefficient)
-
-	for _, light := range s.Lights {
-		lightDirection := light.Position.Subtract(hit.Position)
-		distance := lightDirection.Length()
-		lightDirection = lightDirection.Normalize()
-
-		attenuation := 1 / (1 + 0.1*distance)
-
-		diffuse := m.Color.Scale(m.DiffuseCoefficient * max(0, hit.Normal.Dot(lightDirection)))
-
-		reflectDirection := lightDirection.Negate().Reflect(hit.Normal)
-		viewDirection := ray.Direction.Negate()
-		specular := light.Color.Scale(m.SpecularCoefficient * math.Pow(max(0, viewDirection.Dot(reflectDirection)), m.Shininess))
-
-		lightContribution := diffuse.Add(specular).Scale(attenuation)
-		color = color.Add(lightContribution)
-	}
-
-	color.R = min(1, color.R)
-	color.G = min(1, color.G)
-	color.B = min(1, color.B)
+// Scale returns c with every component multiplied by s.
+func (c *Color) Scale(s float64) *Color {
+	return &Color{c.R * s, c.G * s, c.B * s}
+}
 
-	return color
+// Mul returns the component-wise (Hadamard) product of c and o, which is how
+// BRDF values and light throughput combine along a path.
+func (c *Color) Mul(o *Color) *Color {
+	return &Color{c.R * o.R, c.G * o.G, c.B * o.B}
 }
 
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
+// Max returns the largest of the three color channels, used as the survival
+// probability for Russian-roulette path termination.
+func (c *Color) Max() float64 {
+	return math.Max(c.R, math.Max(c.G, c.B))
 }
 
-func max(a, b float64) float64 {
-	if a > b {
-		return a
-	}
-	return b
+// Material describes how a surface scatters and emits light. It is shared by
+// both the Whitted-style raytracer and the Monte Carlo path tracer, which
+// each interpret Reflectivity/Transparency/Emission through their own
+// shading model. Material intentionally has no dependency on pkg/geometry
+// or pkg/scene: shading code that needs a Hit or a Scene lives in the
+// renderer package that calls it instead, so this package stays a leaf.
+type Material struct {
+	Color               Color
+	Reflectivity        float64
+	Transparency        float64
+	RefractiveIndex     float64
+	AmbientCoefficient  float64
+	DiffuseCoefficient  float64
+	SpecularCoefficient float64
+	Shininess           float64
+	// Emission is the radiance a surface emits on its own, turning any
+	// object carrying a non-zero value into an area light for path tracing.
+	Emission Color
 }
@@ -0,0 +1,363 @@
+// Package pathtracer implements unbiased Monte Carlo path tracing with
+// next-event estimation and Russian-roulette termination, as an alternative
+// to the Whitted-style recursive raytracer in pkg/raytracer.
+package pathtracer
+
+import (
+	"math"
+	"math/rand"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+	"gotracing/pkg/postprocess"
+	"gotracing/pkg/scene"
+	"gotracing/pkg/utility"
+)
+
+// minBounces is how many bounces a path always survives before Russian
+// roulette starts rolling for termination.
+const minBounces = 4
+
+// maxRussianRouletteSurvival caps the survival probability so that very
+// bright throughput doesn't make roulette a no-op.
+const maxRussianRouletteSurvival = 0.95
+
+// Pathtracer renders a scene by integrating the Kajiya rendering equation
+// with independent Monte Carlo samples per pixel.
+type Pathtracer struct {
+	Scene    *scene.Scene
+	Width    int
+	Height   int
+	MaxDepth int
+	Samples  int
+}
+
+func NewPathtracer(scene *scene.Scene, width, height, maxDepth, samples int) *Pathtracer {
+	return &Pathtracer{
+		Scene:    scene,
+		Width:    width,
+		Height:   height,
+		MaxDepth: maxDepth,
+		Samples:  samples,
+	}
+}
+
+// Render returns an unclamped HDR framebuffer; run it through a
+// postprocess.Pipeline to get a displayable image, since a path-traced
+// scene's emitters and caustics routinely exceed 1.0 in radiance.
+func (p *Pathtracer) Render() (*postprocess.Framebuffer, error) {
+	fb := postprocess.NewFramebuffer(p.Width, p.Height)
+	rng := rand.New(rand.NewSource(1))
+
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			accum := &material.Color{}
+
+			for s := 0; s < p.Samples; s++ {
+				u := (float64(x) + utility.Random()) / float64(p.Width-1)
+				v := (float64(y) + utility.Random()) / float64(p.Height-1)
+
+				ray, err := p.Scene.Camera.GetRay(u, v, rng)
+				if err != nil {
+					continue
+				}
+
+				accum = accum.Add(p.tracePath(ray))
+			}
+
+			fb.Set(x, y, accum.Scale(1/float64(p.Samples)))
+		}
+	}
+
+	return fb, nil
+}
+
+// tracePath follows a single camera path, accumulating radiance via
+// Lo = Le + f_r * Li * cos(theta) / pdf at every bounce, combining implicit
+// light hits with explicit next-event estimation against known emitters.
+//
+// A hit's own emission is only added implicitly on the camera ray itself or
+// right after a specular bounce; every other bounce relies solely on
+// sampleDirectLight for direct illumination. NEE can already reach any
+// emitter from a diffuse or glossy vertex, so also adding implicit emission
+// there would count the same light twice and bias the image bright. A
+// specular (delta) bounce is the one case NEE can't sample through, so the
+// implicit hit is the only way that light reaches the path.
+func (p *Pathtracer) tracePath(ray *geometry.Ray) *material.Color {
+	radiance := &material.Color{}
+	throughput := &material.Color{R: 1, G: 1, B: 1}
+	currentRay := ray
+	specularBounce := true
+
+	for bounce := 0; bounce < p.MaxDepth; bounce++ {
+		hit, objPtr := p.Scene.FindClosestIntersection(currentRay)
+		if hit == nil {
+			break
+		}
+
+		object := *objPtr
+		mat := object.Material()
+
+		if specularBounce {
+			radiance = radiance.Add(throughput.Mul(&mat.Emission))
+		}
+		radiance = radiance.Add(throughput.Mul(p.sampleDirectLight(hit, mat, currentRay.Direction)))
+
+		wi, brdf, pdf, specular := sampleBRDF(mat, currentRay.Direction, hit.Normal)
+		if pdf <= 0 {
+			break
+		}
+		specularBounce = specular
+
+		if specular {
+			throughput = throughput.Mul(brdf)
+		} else {
+			cosTheta := math.Max(wi.Dot(hit.Normal), 1e-4)
+			throughput = throughput.Mul(brdf).Scale(cosTheta / pdf)
+		}
+
+		if bounce >= minBounces {
+			survival := math.Min(throughput.Max(), maxRussianRouletteSurvival)
+			if survival <= 0 || utility.Random() > survival {
+				break
+			}
+			throughput = throughput.Scale(1 / survival)
+		}
+
+		currentRay = &geometry.Ray{Origin: hit.Position.Add(wi.Scale(1e-4)), Direction: wi}
+	}
+
+	return radiance
+}
+
+// sampleDirectLight performs next-event estimation: it picks a random
+// emitter, samples a point on it, and — if unoccluded — returns its
+// contribution to the shading point, converted from an area to a solid-angle
+// measure. This is what gives soft shadows without waiting for a path to hit
+// the light by chance. incoming is the direction of the ray that produced
+// hit, needed to evaluate a glossy material's BRDF toward the light the same
+// way sampleBRDF evaluates it for indirect bounces.
+func (p *Pathtracer) sampleDirectLight(hit *geometry.Hit, mat *material.Material, incoming *geometry.Vector) *material.Color {
+	emitters := p.Scene.Emitters
+	if len(emitters) == 0 || mat.Transparency > 0 {
+		return &material.Color{}
+	}
+
+	emitter := emitters[int(utility.Random()*float64(len(emitters)))%len(emitters)]
+	point, lightNormal, area := sampleEmitterPoint(emitter)
+	if point == nil || area <= 0 {
+		return &material.Color{}
+	}
+
+	toLight := point.Subtract(hit.Position)
+	distance := toLight.Length()
+	wi := toLight.Normalize()
+
+	cosSurface := hit.Normal.Dot(wi)
+	cosLight := -lightNormal.Dot(wi)
+	if cosSurface <= 0 || cosLight <= 0 {
+		return &material.Color{}
+	}
+
+	shadowRay := &geometry.Ray{Origin: hit.Position.Add(wi.Scale(1e-4)), Direction: wi}
+	if shadowHit, _ := p.Scene.FindClosestIntersection(shadowRay); shadowHit != nil && shadowHit.T < distance-1e-3 {
+		return &material.Color{}
+	}
+
+	pdfSolidAngle := (distance * distance) / (area * cosLight)
+	brdf := evalBRDF(mat, incoming, hit.Normal, wi)
+	emitterMat := emitter.Material()
+
+	return emitterMat.Emission.Mul(brdf).Scale(cosSurface * float64(len(emitters)) / pdfSolidAngle)
+}
+
+// evalBRDF evaluates the material's BRDF toward wi, dispatching the same way
+// sampleBRDF does so NEE lights a material identically to how its indirect
+// bounces would. Lambertian's value is independent of direction; the Phong
+// lobe isn't, so it needs the incoming ray to rebuild the reflection axis
+// sampleGlossy samples around.
+func evalBRDF(mat *material.Material, incoming, normal, wi *geometry.Vector) *material.Color {
+	if mat.Reflectivity <= 0 {
+		return mat.Color.Scale(1 / math.Pi)
+	}
+
+	reflectDir := utility.Reflect(incoming, normal).Normalize()
+	exponent := math.Max(mat.Shininess, 1)
+	lobe := (exponent + 2) / (2 * math.Pi) * math.Pow(math.Max(wi.Dot(reflectDir), 0), exponent)
+
+	return mat.Color.Scale(mat.Reflectivity * lobe)
+}
+
+// sampleBRDF draws an outgoing direction from the material's BRDF and
+// returns the direction, the BRDF value, its pdf, and whether the bounce is
+// a delta (perfectly specular) lobe for which cos(theta)/pdf is already 1.
+func sampleBRDF(mat *material.Material, incoming, normal *geometry.Vector) (wi *geometry.Vector, brdf *material.Color, pdf float64, specular bool) {
+	switch {
+	case mat.Transparency > 0:
+		wi := sampleDielectric(mat, incoming, normal)
+		return wi, &material.Color{R: 1, G: 1, B: 1}, 1, true
+	case mat.Reflectivity > 0:
+		return sampleGlossy(mat, incoming, normal)
+	default:
+		return sampleDiffuse(mat, normal)
+	}
+}
+
+// sampleDiffuse cosine-weight samples the hemisphere above normal for a
+// Lambertian surface, for which pdf and brdf cancel to just the albedo.
+func sampleDiffuse(mat *material.Material, normal *geometry.Vector) (*geometry.Vector, *material.Color, float64, bool) {
+	wi := cosineSampleHemisphere(normal)
+	cosTheta := math.Max(wi.Dot(normal), 1e-4)
+	pdf := cosTheta / math.Pi
+	brdf := mat.Color.Scale(1 / math.Pi)
+	return wi, brdf, pdf, false
+}
+
+// sampleGlossy samples a Phong lobe around the perfect reflection direction,
+// giving rough mirrors a blurred specular highlight rather than a sharp one.
+func sampleGlossy(mat *material.Material, incoming, normal *geometry.Vector) (*geometry.Vector, *material.Color, float64, bool) {
+	reflectDir := utility.Reflect(incoming, normal).Normalize()
+	exponent := math.Max(mat.Shininess, 1)
+
+	r1 := utility.Random()
+	r2 := utility.Random()
+	cosTheta := math.Pow(1-r1, 1/(exponent+1))
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * math.Pi * r2
+
+	tangent, bitangent := buildONB(reflectDir)
+	wi := tangent.Scale(sinTheta * math.Cos(phi)).
+		Add(bitangent.Scale(sinTheta * math.Sin(phi))).
+		Add(reflectDir.Scale(cosTheta)).
+		Normalize()
+
+	if wi.Dot(normal) <= 0 {
+		return wi, &material.Color{}, 1, false
+	}
+
+	pdf := (exponent + 1) / (2 * math.Pi) * math.Pow(cosTheta, exponent)
+	lobe := (exponent + 2) / (2 * math.Pi) * math.Pow(math.Max(wi.Dot(reflectDir), 0), exponent)
+	brdf := mat.Color.Scale(mat.Reflectivity * lobe)
+
+	return wi, brdf, pdf, false
+}
+
+// sampleDielectric reuses the existing reflect/refract primitives to bounce
+// a path through a perfectly specular transparent surface, falling back to
+// reflection under total internal reflection.
+func sampleDielectric(mat *material.Material, incoming, normal *geometry.Vector) *geometry.Vector {
+	n := normal
+	eta := 1 / mat.RefractiveIndex
+	if incoming.Dot(normal) > 0 {
+		n = normal.Scale(-1)
+		eta = mat.RefractiveIndex
+	}
+
+	refracted, totalInternalReflection := utility.Refract(incoming, n, eta)
+	if totalInternalReflection {
+		return utility.Reflect(incoming, n).Normalize()
+	}
+	return refracted.Normalize()
+}
+
+// sampleEmitterPoint draws a uniformly random point (and its outward normal
+// and surface area) on an emitter for next-event estimation. Object types
+// that have no natural area-sampling routine (e.g. Plane, which is
+// unbounded) are simply not usable as explicit light sources.
+func sampleEmitterPoint(obj geometry.Object) (point, normal *geometry.Vector, area float64) {
+	switch o := obj.(type) {
+	case *geometry.Sphere:
+		dir := uniformSphereSample()
+		return o.Center.Add(dir.Scale(o.Radius)), dir, 4 * math.Pi * o.Radius * o.Radius
+	case *geometry.Triangle:
+		u := utility.Random()
+		v := utility.Random()
+		if u+v > 1 {
+			u, v = 1-u, 1-v
+		}
+		edge1 := o.V1.Subtract(o.V0)
+		edge2 := o.V2.Subtract(o.V0)
+		point := o.V0.Add(edge1.Scale(u)).Add(edge2.Scale(v))
+		cross := edge1.Cross(edge2)
+		return point, cross.Normalize(), 0.5 * cross.Length()
+	case *geometry.Mesh:
+		return sampleMeshPoint(o)
+	default:
+		return nil, nil, 0
+	}
+}
+
+// sampleMeshPoint picks a face weighted by its area — so a point on a big
+// face isn't under-sampled relative to a small one — then samples a
+// uniformly random point on it the same way the Triangle case does. The
+// returned area is the mesh's total surface area, since that's the measure
+// the uniform-per-unit-area density above is defined over.
+func sampleMeshPoint(m *geometry.Mesh) (point, normal *geometry.Vector, area float64) {
+	if len(m.Faces) == 0 {
+		return nil, nil, 0
+	}
+
+	faceAreas := make([]float64, len(m.Faces))
+	totalArea := 0.0
+	for i, face := range m.Faces {
+		edge1 := m.Vertices[face.V1].Subtract(m.Vertices[face.V0])
+		edge2 := m.Vertices[face.V2].Subtract(m.Vertices[face.V0])
+		faceAreas[i] = 0.5 * edge1.Cross(edge2).Length()
+		totalArea += faceAreas[i]
+	}
+	if totalArea <= 0 {
+		return nil, nil, 0
+	}
+
+	target := utility.Random() * totalArea
+	face := m.Faces[len(m.Faces)-1]
+	for i, a := range faceAreas {
+		if target -= a; target <= 0 {
+			face = m.Faces[i]
+			break
+		}
+	}
+
+	u := utility.Random()
+	v := utility.Random()
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	edge1 := m.Vertices[face.V1].Subtract(m.Vertices[face.V0])
+	edge2 := m.Vertices[face.V2].Subtract(m.Vertices[face.V0])
+	point = m.Vertices[face.V0].Add(edge1.Scale(u)).Add(edge2.Scale(v))
+
+	return point, edge1.Cross(edge2).Normalize(), totalArea
+}
+
+func uniformSphereSample() *geometry.Vector {
+	z := 1 - 2*utility.Random()
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	phi := 2 * math.Pi * utility.Random()
+	return &geometry.Vector{X: r * math.Cos(phi), Y: r * math.Sin(phi), Z: z}
+}
+
+func cosineSampleHemisphere(normal *geometry.Vector) *geometry.Vector {
+	r1 := utility.Random()
+	r2 := utility.Random()
+	phi := 2 * math.Pi * r1
+	sinTheta := math.Sqrt(r2)
+	cosTheta := math.Sqrt(1 - r2)
+
+	tangent, bitangent := buildONB(normal)
+	dir := tangent.Scale(sinTheta * math.Cos(phi)).
+		Add(bitangent.Scale(sinTheta * math.Sin(phi))).
+		Add(normal.Scale(cosTheta))
+	return dir.Normalize()
+}
+
+// buildONB constructs an orthonormal tangent/bitangent basis around n.
+func buildONB(n *geometry.Vector) (tangent, bitangent *geometry.Vector) {
+	a := &geometry.Vector{X: 1, Y: 0, Z: 0}
+	if math.Abs(n.X) > 0.9 {
+		a = &geometry.Vector{X: 0, Y: 1, Z: 0}
+	}
+	tangent = a.Cross(n).Normalize()
+	bitangent = n.Cross(tangent)
+	return tangent, bitangent
+}
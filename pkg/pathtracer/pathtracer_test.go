@@ -0,0 +1,106 @@
+package pathtracer
+
+import (
+	"testing"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+	"gotracing/pkg/scene"
+)
+
+// averageDirectLight samples sampleDirectLight many times and averages the
+// result, since it's a Monte Carlo estimator with one random emitter pick
+// and one random point on it per call.
+func averageDirectLight(p *Pathtracer, hit *geometry.Hit, mat *material.Material, incoming *geometry.Vector, trials int) float64 {
+	total := 0.0
+	for i := 0; i < trials; i++ {
+		total += p.sampleDirectLight(hit, mat, incoming).R
+	}
+	return total / float64(trials)
+}
+
+// TestTracePathDoesNotDoubleCountEmission reproduces the exact shape of the
+// MIS bug: a camera ray hits a diffuse, non-emissive floor plane at z=0,
+// whose diffuse bounce is always aimed into the +Z hemisphere and so then
+// hits a huge emissive plane at z=10 (Plane.Intersect rejects negative t, so
+// the bounce can't hit the floor again). sampleEmitterPoint doesn't know how
+// to sample a Plane, so NEE contributes exactly zero on both bounces here,
+// isolating the bug: without the specular-bounce gate, the diffuse bounce's
+// implicit hit would add the emitter's full emission a second time, since
+// throughput after an importance-sampled Lambertian bounce with a white
+// albedo is exactly (1,1,1). A correctly gated path must return black.
+func TestTracePathDoesNotDoubleCountEmission(t *testing.T) {
+	diffuseFloor := &geometry.Plane{
+		Point:  &geometry.Vector{X: 0, Y: 0, Z: 0},
+		Normal: &geometry.Vector{X: 0, Y: 0, Z: 1},
+		Mat:    &material.Material{Color: material.Color{R: 1, G: 1, B: 1}},
+	}
+	emissivePlane := &geometry.Plane{
+		Point:  &geometry.Vector{X: 0, Y: 0, Z: 10},
+		Normal: &geometry.Vector{X: 0, Y: 0, Z: -1},
+		Mat: &material.Material{
+			Emission: material.Color{R: 1, G: 1, B: 1},
+		},
+	}
+
+	s := scene.NewScene(nil)
+	s.AddObject(diffuseFloor)
+	s.AddObject(emissivePlane)
+
+	p := NewPathtracer(s, 1, 1, 2, 1)
+
+	ray := &geometry.Ray{
+		Origin:    &geometry.Vector{X: 0, Y: 0, Z: 5},
+		Direction: &geometry.Vector{X: 0, Y: 0, Z: -1},
+	}
+
+	const trials = 20
+	for i := 0; i < trials; i++ {
+		radiance := p.tracePath(ray)
+		if radiance.R > 1e-6 || radiance.G > 1e-6 || radiance.B > 1e-6 {
+			t.Fatalf("trial %d: expected no radiance (NEE can't reach the plane and the\n"+
+				"emitter must not be double-counted on the diffuse bounce), got %+v", i, radiance)
+		}
+	}
+}
+
+// TestSampleDirectLightScalesWithEmitterCount reproduces the light-selection
+// pdf bug directly: adding more co-located, identical emitters to a scene
+// must not make NEE's estimate of the direct lighting at a point dimmer,
+// since each additional light is real, independent illumination the
+// estimator needs to average over, not divide away. The correct estimate
+// grows roughly in proportion to N; dividing by len(emitters) where it
+// should multiply leaves it at roughly 1/N^2 of that, so it shrinks instead.
+func TestSampleDirectLightScalesWithEmitterCount(t *testing.T) {
+	hit := &geometry.Hit{
+		Position: &geometry.Vector{X: 0, Y: 0, Z: 0},
+		Normal:   &geometry.Vector{X: 0, Y: 0, Z: 1},
+	}
+	mat := &material.Material{Color: material.Color{R: 1, G: 1, B: 1}}
+	incoming := &geometry.Vector{X: 0, Y: 0, Z: -1}
+
+	newLight := func() *geometry.Sphere {
+		return &geometry.Sphere{
+			Center: &geometry.Vector{X: 0, Y: 0, Z: 5},
+			Radius: 1,
+			Mat:    &material.Material{Emission: material.Color{R: 1, G: 1, B: 1}},
+		}
+	}
+
+	const trials = 20000
+	averages := make([]float64, 0, 3)
+	for _, n := range []int{1, 2, 4} {
+		s := scene.NewScene(nil)
+		for i := 0; i < n; i++ {
+			s.AddObject(newLight())
+		}
+		p := NewPathtracer(s, 1, 1, 1, 1)
+		averages = append(averages, averageDirectLight(p, hit, mat, incoming, trials))
+	}
+
+	for i := 1; i < len(averages); i++ {
+		if averages[i] < averages[i-1]*0.7 {
+			t.Fatalf("average direct light must not shrink as emitters are added, got %v", averages)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package postprocess turns an unclamped, floating-point HDR framebuffer
+// produced by a renderer into a displayable 8-bit image, via a chain of
+// exposure, tonemapping, gamma, and bloom stages.
+package postprocess
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gotracing/pkg/material"
+	"gotracing/pkg/utility"
+)
+
+// Framebuffer is an unclamped HDR image: a renderer accumulates radiance
+// into it directly, with no per-pixel clamping, so a Pipeline can recover
+// bright highlights that a naive clamp-to-[0,1] would have thrown away.
+type Framebuffer struct {
+	Width, Height int
+	Pixels        []material.Color
+}
+
+func NewFramebuffer(width, height int) *Framebuffer {
+	return &Framebuffer{Width: width, Height: height, Pixels: make([]material.Color, width*height)}
+}
+
+func (f *Framebuffer) Set(x, y int, c *material.Color) {
+	f.Pixels[y*f.Width+x] = *c
+}
+
+func (f *Framebuffer) At(x, y int) material.Color {
+	return f.Pixels[y*f.Width+x]
+}
+
+// Tonemapper selects which tonemapping curve a Tonemap stage applies.
+type Tonemapper int
+
+const (
+	// Reinhard is the simple c/(1+c) operator.
+	Reinhard Tonemapper = iota
+	// ACESApprox is the widely-used polynomial fit to the ACES filmic curve.
+	ACESApprox
+)
+
+func (t Tonemapper) apply(c float64) float64 {
+	switch t {
+	case ACESApprox:
+		return (c * (2.51*c + 0.03)) / (c*(2.43*c+0.59) + 0.14)
+	default:
+		return c / (1 + c)
+	}
+}
+
+// Stage is one step of a post-process Pipeline.
+type Stage interface {
+	Apply(fb *Framebuffer)
+}
+
+// Pipeline runs a chain of Stages over a Framebuffer in order, finishing
+// with conversion to an 8-bit *image.RGBA.
+type Pipeline struct {
+	Stages []Stage
+}
+
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{Stages: stages}
+}
+
+// Process runs every stage in order and converts the result to an
+// *image.RGBA; ConvertColorToUint8 is effectively the pipeline's final
+// stage.
+func (p *Pipeline) Process(fb *Framebuffer) *image.RGBA {
+	for _, stage := range p.Stages {
+		stage.Apply(fb)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, fb.Width, fb.Height))
+	for y := 0; y < fb.Height; y++ {
+		for x := 0; x < fb.Width; x++ {
+			c := fb.At(x, y)
+			r, g, b, a := utility.ConvertColorToUint8(&c)
+			img.Set(x, y, color.RGBA{r, g, b, a})
+		}
+	}
+	return img
+}
+
+// Exposure scales every pixel by Scale before tonemapping, the same role an
+// exposure knob plays on a camera.
+type Exposure struct {
+	Scale float64
+}
+
+func (e Exposure) Apply(fb *Framebuffer) {
+	for i, c := range fb.Pixels {
+		fb.Pixels[i] = material.Color{R: c.R * e.Scale, G: c.G * e.Scale, B: c.B * e.Scale}
+	}
+}
+
+// Tonemap compresses unbounded HDR radiance into the [0,1] range using the
+// selected Tonemapper.
+type Tonemap struct {
+	Operator Tonemapper
+}
+
+func (t Tonemap) Apply(fb *Framebuffer) {
+	for i, c := range fb.Pixels {
+		fb.Pixels[i] = material.Color{
+			R: t.Operator.apply(c.R),
+			G: t.Operator.apply(c.G),
+			B: t.Operator.apply(c.B),
+		}
+	}
+}
+
+// Gamma applies a pow(c, 1/Gamma) correction; Gamma = 2.2 matches sRGB.
+type Gamma struct {
+	Gamma float64
+}
+
+func (g Gamma) Apply(fb *Framebuffer) {
+	invGamma := 1 / g.Gamma
+	for i, c := range fb.Pixels {
+		fb.Pixels[i] = material.Color{
+			R: math.Pow(math.Max(c.R, 0), invGamma),
+			G: math.Pow(math.Max(c.G, 0), invGamma),
+			B: math.Pow(math.Max(c.B, 0), invGamma),
+		}
+	}
+}
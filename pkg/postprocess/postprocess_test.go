@@ -0,0 +1,100 @@
+package postprocess
+
+import (
+	"math"
+	"testing"
+
+	"gotracing/pkg/material"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestTonemapReinhardCompressesToUnitRange checks Reinhard's c/(1+c) curve:
+// it should map 0 to 0, grow monotonically, and asymptote toward (but never
+// reach) 1 for arbitrarily bright input.
+func TestTonemapReinhardCompressesToUnitRange(t *testing.T) {
+	cases := []struct {
+		in, want float64
+	}{
+		{0, 0},
+		{1, 0.5},
+		{3, 0.75},
+	}
+	for _, c := range cases {
+		got := Reinhard.apply(c.in)
+		if !approxEqual(got, c.want) {
+			t.Errorf("Reinhard.apply(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+
+	if got := Reinhard.apply(1e6); got >= 1 {
+		t.Errorf("Reinhard.apply(1e6) = %v, expected strictly less than 1", got)
+	}
+}
+
+// TestTonemapACESApproxMapsZeroToZero checks the boundary condition every
+// tonemap curve must satisfy: a black pixel stays black.
+func TestTonemapACESApproxMapsZeroToZero(t *testing.T) {
+	if got := ACESApprox.apply(0); !approxEqual(got, 0) {
+		t.Errorf("ACESApprox.apply(0) = %v, want 0", got)
+	}
+}
+
+// TestGammaRoundTripsOne checks that Gamma.Apply leaves a pixel at exactly
+// 1.0 unchanged, since pow(1, x) == 1 regardless of the gamma exponent.
+func TestGammaRoundTripsOne(t *testing.T) {
+	fb := NewFramebuffer(1, 1)
+	fb.Set(0, 0, &material.Color{R: 1, G: 1, B: 1})
+
+	Gamma{Gamma: 2.2}.Apply(fb)
+
+	got := fb.At(0, 0)
+	if !approxEqual(got.R, 1) || !approxEqual(got.G, 1) || !approxEqual(got.B, 1) {
+		t.Fatalf("expected (1,1,1) to round-trip through gamma correction unchanged, got %+v", got)
+	}
+}
+
+// TestBloomLeavesDimPixelsUntouched checks that a framebuffer with nothing
+// above Bloom's threshold comes back bit-for-bit identical: thresholdColor
+// should zero out every contribution before the blur/composite step runs.
+func TestBloomLeavesDimPixelsUntouched(t *testing.T) {
+	fb := NewFramebuffer(4, 4)
+	for i := range fb.Pixels {
+		fb.Pixels[i] = material.Color{R: 0.5, G: 0.5, B: 0.5}
+	}
+
+	Bloom{Threshold: 1.0, Downsample: 1, KernelRadius: 1, Intensity: 1.0}.Apply(fb)
+
+	for i, c := range fb.Pixels {
+		if !approxEqual(c.R, 0.5) || !approxEqual(c.G, 0.5) || !approxEqual(c.B, 0.5) {
+			t.Fatalf("pixel %d: expected bloom to leave a sub-threshold pixel unchanged, got %+v", i, c)
+		}
+	}
+}
+
+// TestBloomSpreadsBrightPixelToNeighbors checks that a single pixel above
+// threshold, surrounded by black, bleeds glow into its neighbors after the
+// blur — the whole point of bloom — while staying at least as bright itself.
+func TestBloomSpreadsBrightPixelToNeighbors(t *testing.T) {
+	fb := NewFramebuffer(15, 15)
+	fb.Set(7, 7, &material.Color{R: 10, G: 10, B: 10})
+
+	Bloom{Threshold: 1.0, Downsample: 1, KernelRadius: 2, Intensity: 1.0}.Apply(fb)
+
+	center := fb.At(7, 7)
+	if center.R <= 10 {
+		t.Fatalf("expected the bright pixel to stay at least as bright after bloom, got %+v", center)
+	}
+
+	neighbor := fb.At(7, 8)
+	if neighbor.R <= 0 {
+		t.Fatalf("expected a neighboring pixel to pick up some glow from bloom, got %+v", neighbor)
+	}
+
+	corner := fb.At(0, 0)
+	if corner.R != 0 {
+		t.Fatalf("expected a far corner to receive no glow from a radius-2 kernel, got %+v", corner)
+	}
+}
@@ -2,14 +2,24 @@ package raytracer
 
 import (
 	"fmt"
-	"image"
+	"math"
+	"math/rand"
+	"runtime"
 	"sync"
+	"sync/atomic"
+
 	"gotracing/pkg/geometry"
 	"gotracing/pkg/material"
+	"gotracing/pkg/postprocess"
 	"gotracing/pkg/scene"
 	"gotracing/pkg/utility"
 )
 
+// tileSize is the width and height, in pixels, of a unit of render work.
+// Tiles are small enough to load-balance well across workers but large
+// enough that each worker stays in a cache-friendly region of the image.
+const tileSize = 32
+
 type Raytracer struct {
 	Scene    *scene.Scene
 	Width    int
@@ -28,82 +38,153 @@ func NewRaytracer(scene *scene.Scene, width, height, maxDepth, samples int) *Ray
 	}
 }
 
-func (r *Raytracer) Render() (*image.RGBA, error) {
-	img := image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))
+// tile is a rectangular region of the image, exclusive of x1/y1.
+type tile struct {
+	x0, y0, x1, y1 int
+}
+
+// buildTiles splits a width x height image into fixed-size tiles.
+func buildTiles(width, height, size int) []tile {
+	var tiles []tile
+	for y := 0; y < height; y += size {
+		for x := 0; x < width; x += size {
+			tiles = append(tiles, tile{
+				x0: x, y0: y,
+				x1: min(x+size, width), y1: min(y+size, height),
+			})
+		}
+	}
+	return tiles
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Render splits the image into tiles and renders them with a bounded pool of
+// runtime.NumCPU() workers, each pulling tiles off a shared queue and
+// rendering every pixel in its tile sequentially with its own *rand.Rand so
+// samples don't contend on the global lock inside math/rand. The result is
+// an unclamped HDR framebuffer; run it through a postprocess.Pipeline to get
+// a displayable image.
+func (r *Raytracer) Render() (*postprocess.Framebuffer, error) {
+	fb := postprocess.NewFramebuffer(r.Width, r.Height)
+
+	tiles := buildTiles(r.Width, r.Height, tileSize)
+	tileQueue := make(chan tile, len(tiles))
+	for _, t := range tiles {
+		tileQueue <- t
+	}
+	close(tileQueue)
 
+	workers := runtime.NumCPU()
+	var completedTiles int64
 	var wg sync.WaitGroup
-	pixels := r.Width * r.Height
-	wg.Add(pixels)
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(workerID int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(workerID) + 1))
+
+			for t := range tileQueue {
+				r.renderTile(fb, t, rng)
+				done := atomic.AddInt64(&completedTiles, 1)
+				fmt.Printf("\rRendering... %d%% complete", 100*done/int64(len(tiles)))
+			}
+		}(w)
+	}
 
-	for y := 0; y < r.Height; y++ {
-		for x := 0; x < r.Width; x++ {
-			go func(x, y int) {
-				defer wg.Done()
+	wg.Wait()
 
-				color := &material.Color{}
+	fmt.Println("\rRendering... done")
 
-				for s := 0; s < r.Samples; s++ {
-					u := (float64(x) + utility.Random()) / float64(r.Width-1)
-					v := (float64(y) + utility.Random()) / float64(r.Height-1)
+	return fb, nil
+}
 
-					ray, err := r.Scene.Camera.GetRay(u, v)
+// stratum is the N in the NxN grid that Samples = N*N pixel samples are
+// stratified into; any remainder past N*N is dropped rather than biasing
+// one cell with an extra sample.
+func stratum(samples int) int {
+	n := int(math.Sqrt(float64(samples)))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func (r *Raytracer) renderTile(fb *postprocess.Framebuffer, t tile, rng *rand.Rand) {
+	n := stratum(r.Samples)
+
+	for y := t.y0; y < t.y1; y++ {
+		for x := t.x0; x < t.x1; x++ {
+			pixelColor := &material.Color{}
+
+			for sy := 0; sy < n; sy++ {
+				for sx := 0; sx < n; sx++ {
+					u := (float64(x) + (float64(sx)+rng.Float64())/float64(n)) / float64(r.Width-1)
+					v := (float64(y) + (float64(sy)+rng.Float64())/float64(n)) / float64(r.Height-1)
+
+					ray, err := r.Scene.Camera.GetRay(u, v, rng)
 					if err != nil {
-						// handle error
-						return
+						continue
 					}
 
-					sampleColor := r.traceRay(ray, r.MaxDepth)
-
-					color = color.Add(sampleColor)
+					pixelColor = pixelColor.Add(r.traceRay(ray, r.MaxDepth, false))
 				}
+			}
 
-				color = color.Scale(1 / float64(r.Samples))
-
-				rgba := color.ToRGBA()
-				img.Set(x, y, rgba)
-			}(x, y)
+			fb.Set(x, y, pixelColor.Scale(1/float64(n*n)))
 		}
-		fmt.Printf("\rRendering... %d%% complete", 100*(r.Width*y+x)/pixels)
 	}
-
-	wg.Wait()
-
-	fmt.Println("\rRendering... done")
-
-	return img, nil
 }
 
-
 func (r *Raytracer) traceRay(ray *geometry.Ray, depth int, inside bool) *material.Color {
 	if depth <= 0 {
-		return &material.Color{0, 0, 0} 
+		return &material.Color{R: 0, G: 0, B: 0}
 	}
 
-	hit, object := r.Scene.FindClosestIntersection(ray)
+	hit, objPtr := r.Scene.FindClosestIntersection(ray)
 	if hit == nil {
-		return &material.Color{0, 0, 0} 
+		return &material.Color{R: 0, G: 0, B: 0}
 	}
 
-	hitColor := object.Material.ComputeColor(hit, r.Scene, ray)
+	object := *objPtr
+	mat := object.Material()
+
+	hitColor := computeColor(hit, mat, r.Scene, ray)
 
-	if object.Material.Reflectivity > 0 {
-		reflectionRay := ray.Reflect(hit.Normal)
+	if mat.Reflectivity > 0 {
+		reflectDir := utility.Reflect(ray.Direction, hit.Normal).Normalize()
+		reflectionRay := &geometry.Ray{Origin: hit.Position.Add(reflectDir.Scale(1e-4)), Direction: reflectDir}
 		reflectedColor := r.traceRay(reflectionRay, depth-1, inside)
-		hitColor = hitColor.Add(reflectedColor.Scale(object.Material.Reflectivity))
+		hitColor = hitColor.Add(reflectedColor.Scale(mat.Reflectivity))
 	}
 
-	if object.Material.Transparency > 0 {
-		refractionRay, totalInternalReflection := ray.Refract(hit.Normal, object.Material.RefractiveIndex, inside)
+	if mat.Transparency > 0 {
+		n := hit.Normal
+		eta := 1 / mat.RefractiveIndex
+		if inside {
+			n = hit.Normal.Scale(-1)
+			eta = mat.RefractiveIndex
+		}
+
+		refractedDir, totalInternalReflection := utility.Refract(ray.Direction, n, eta)
 		if totalInternalReflection {
-			reflectedColor := r.traceRay(refractionRay, depth-1, inside)
-			hitColor = hitColor.Add(reflectedColor.Scale(object.Material.Transparency))
+			reflectDir := utility.Reflect(ray.Direction, n).Normalize()
+			reflectionRay := &geometry.Ray{Origin: hit.Position.Add(reflectDir.Scale(1e-4)), Direction: reflectDir}
+			reflectedColor := r.traceRay(reflectionRay, depth-1, inside)
+			hitColor = hitColor.Add(reflectedColor.Scale(mat.Transparency))
 		} else {
+			refractedDir = refractedDir.Normalize()
+			refractionRay := &geometry.Ray{Origin: hit.Position.Add(refractedDir.Scale(1e-4)), Direction: refractedDir}
 			refractedColor := r.traceRay(refractionRay, depth-1, !inside)
-			hitColor = hitColor.Add(refractedColor.Scale(object.Material.Transparency))
+			hitColor = hitColor.Add(refractedColor.Scale(mat.Transparency))
 		}
 	}
 
 	return hitColor
 }
-
-
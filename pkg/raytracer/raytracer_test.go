@@ -0,0 +1,54 @@
+package raytracer
+
+import (
+	"testing"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+	"gotracing/pkg/scene"
+)
+
+// TestRenderProducesAnImage builds a minimal scene (one lit sphere) and
+// checks that Render actually traces something: the pixel at the center of
+// the image, where the sphere fills the frame, should differ from the black
+// background a miss would leave behind.
+func TestRenderProducesAnImage(t *testing.T) {
+	camera := geometry.NewCamera(
+		&geometry.Vector{X: 0, Y: 0, Z: 3},
+		&geometry.Vector{X: 0, Y: 0, Z: 0},
+		&geometry.Vector{X: 0, Y: 1, Z: 0},
+		45, 1, 0, 3,
+	)
+
+	s := scene.NewScene(camera)
+	s.AddLight(scene.Light{
+		Position: &geometry.Vector{X: 2, Y: 2, Z: 2},
+		Color:    material.Color{R: 1, G: 1, B: 1},
+	})
+	s.AddObject(&geometry.Sphere{
+		Center: &geometry.Vector{X: 0, Y: 0, Z: 0},
+		Radius: 1,
+		Mat: &material.Material{
+			Color:              material.Color{R: 0.8, G: 0.2, B: 0.2},
+			AmbientCoefficient: 0.1,
+			DiffuseCoefficient: 0.8,
+		},
+	})
+	s.Build()
+
+	r := NewRaytracer(s, 32, 32, 5, 1)
+	fb, err := r.Render()
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	center := fb.At(16, 16)
+	if center.R == 0 && center.G == 0 && center.B == 0 {
+		t.Fatalf("expected a non-black pixel at the image center where the sphere should be hit, got %+v", center)
+	}
+
+	corner := fb.At(0, 0)
+	if corner.R != 0 || corner.G != 0 || corner.B != 0 {
+		t.Fatalf("expected the image corner to miss the sphere and stay black, got %+v", corner)
+	}
+}
@@ -0,0 +1,42 @@
+package raytracer
+
+import (
+	"math"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+	"gotracing/pkg/scene"
+)
+
+// computeColor evaluates simple Phong direct lighting at hit against
+// every light in the scene: ambient + diffuse + specular, attenuated by
+// distance. This is the Whitted-style shading term traceRay adds to a hit
+// before layering in reflection/refraction. It lives here rather than as a
+// Material method so pkg/material doesn't need to depend on pkg/geometry or
+// pkg/scene.
+func computeColor(hit *geometry.Hit, mat *material.Material, sc *scene.Scene, ray *geometry.Ray) *material.Color {
+	color := mat.Color.Scale(mat.AmbientCoefficient)
+
+	for _, light := range sc.Lights {
+		lightDirection := light.Position.Subtract(hit.Position)
+		distance := lightDirection.Length()
+		lightDirection = lightDirection.Normalize()
+
+		attenuation := 1 / (1 + 0.1*distance)
+
+		diffuse := mat.Color.Scale(mat.DiffuseCoefficient * math.Max(0, hit.Normal.Dot(lightDirection)))
+
+		reflectDirection := lightDirection.Negate().Reflect(hit.Normal)
+		viewDirection := ray.Direction.Negate()
+		specular := light.Color.Scale(mat.SpecularCoefficient * math.Pow(math.Max(0, viewDirection.Dot(reflectDirection)), mat.Shininess))
+
+		lightContribution := diffuse.Add(specular).Scale(attenuation)
+		color = color.Add(lightContribution)
+	}
+
+	color.R = math.Min(1, color.R)
+	color.G = math.Min(1, color.G)
+	color.B = math.Min(1, color.B)
+
+	return color
+}
@@ -0,0 +1,311 @@
+package scene
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+)
+
+// defaultAspectRatio matches cmd/main.go's hardcoded 800x600 output; there is
+// currently no directive to override it from the scene file.
+const defaultAspectRatio = 800.0 / 600.0
+
+// LoadScene parses a scene description file into a Scene. The format is a
+// simple line-oriented directive language, one directive per line, with
+// "key=value" arguments and blank lines/"#" comments ignored:
+//
+//	material <name> color=r,g,b reflectivity=f transparency=f
+//	                 refractive_index=f ambient=f diffuse=f specular=f
+//	                 shininess=f emission=r,g,b
+//	camera lookfrom=x,y,z lookat=x,y,z up=x,y,z vfov=deg
+//	light pos=x,y,z color=r,g,b
+//	sphere center=x,y,z radius=f material=<name>
+//	plane point=x,y,z normal=x,y,z material=<name>
+//	triangle v0=x,y,z v1=x,y,z v2=x,y,z material=<name>
+//	mesh <path> material=<name>
+//
+// A material must be declared before anything references it, and at least
+// one camera directive is required; a later camera line replaces an earlier
+// one.
+func LoadScene(path string) (*Scene, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening scene file: %w", err)
+	}
+	defer file.Close()
+
+	materials := map[string]*material.Material{}
+	s := NewScene(nil)
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		directive := fields[0]
+		args, err := parseDirectiveArgs(fields[1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		if err := applyDirective(s, materials, directive, fields, args); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading scene file: %w", err)
+	}
+
+	if s.Camera == nil {
+		return nil, fmt.Errorf("scene file must declare a camera")
+	}
+
+	return s, nil
+}
+
+// applyDirective dispatches a single parsed line to the handler for its
+// directive, mutating s (and, for "material" lines, the materials registry)
+// in place.
+func applyDirective(s *Scene, materials map[string]*material.Material, directive string, fields []string, args map[string]string) error {
+	switch directive {
+	case "material":
+		if len(fields) < 2 {
+			return fmt.Errorf("material directive needs a name")
+		}
+		materials[fields[1]] = parseMaterial(args)
+		return nil
+
+	case "camera":
+		camera, err := parseCamera(args)
+		if err != nil {
+			return err
+		}
+		s.Camera = camera
+		return nil
+
+	case "light":
+		light, err := parseLight(args)
+		if err != nil {
+			return err
+		}
+		s.AddLight(light)
+		return nil
+
+	case "sphere":
+		mat, err := resolveMaterial(materials, args)
+		if err != nil {
+			return err
+		}
+		center, err := parseVector(args, "center")
+		if err != nil {
+			return err
+		}
+		radius, err := parseFloat(args, "radius")
+		if err != nil {
+			return err
+		}
+		s.AddObject(&geometry.Sphere{Center: center, Radius: radius, Mat: mat})
+		return nil
+
+	case "plane":
+		mat, err := resolveMaterial(materials, args)
+		if err != nil {
+			return err
+		}
+		point, err := parseVector(args, "point")
+		if err != nil {
+			return err
+		}
+		normal, err := parseVector(args, "normal")
+		if err != nil {
+			return err
+		}
+		s.AddObject(&geometry.Plane{Point: point, Normal: normal.Normalize(), Mat: mat})
+		return nil
+
+	case "triangle":
+		mat, err := resolveMaterial(materials, args)
+		if err != nil {
+			return err
+		}
+		v0, err := parseVector(args, "v0")
+		if err != nil {
+			return err
+		}
+		v1, err := parseVector(args, "v1")
+		if err != nil {
+			return err
+		}
+		v2, err := parseVector(args, "v2")
+		if err != nil {
+			return err
+		}
+		s.AddObject(&geometry.Triangle{V0: v0, V1: v1, V2: v2, Mat: mat})
+		return nil
+
+	case "mesh":
+		if len(fields) < 2 {
+			return fmt.Errorf("mesh directive needs a path")
+		}
+		mat, err := resolveMaterial(materials, args)
+		if err != nil {
+			return err
+		}
+		mesh, err := LoadOBJ(fields[1], mat)
+		if err != nil {
+			return fmt.Errorf("loading mesh %q: %w", fields[1], err)
+		}
+		s.AddObject(mesh)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown directive %q", directive)
+	}
+}
+
+// parseDirectiveArgs turns ["key=value", ...] into a lookup map.
+func parseDirectiveArgs(fields []string) (map[string]string, error) {
+	args := map[string]string{}
+	for _, field := range fields {
+		if !strings.Contains(field, "=") {
+			continue // positional argument (e.g. a material's name), not key=value
+		}
+		parts := strings.SplitN(field, "=", 2)
+		args[parts[0]] = parts[1]
+	}
+	return args, nil
+}
+
+func parseFloat(args map[string]string, key string) (float64, error) {
+	raw, ok := args[key]
+	if !ok {
+		return 0, fmt.Errorf("missing %q argument", key)
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %q value %q: %w", key, raw, err)
+	}
+	return v, nil
+}
+
+func parseFloatOr(args map[string]string, key string, fallback float64) float64 {
+	v, err := parseFloat(args, key)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func parseVector(args map[string]string, key string) (*geometry.Vector, error) {
+	raw, ok := args[key]
+	if !ok {
+		return nil, fmt.Errorf("missing %q argument", key)
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%q expects 3 comma-separated components, got %q", key, raw)
+	}
+	x, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q: %w", key, err)
+	}
+	y, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q: %w", key, err)
+	}
+	z, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q: %w", key, err)
+	}
+	return &geometry.Vector{X: x, Y: y, Z: z}, nil
+}
+
+func parseColor(args map[string]string, key string, fallback material.Color) material.Color {
+	raw, ok := args[key]
+	if !ok {
+		return fallback
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) != 3 {
+		return fallback
+	}
+	r, err1 := strconv.ParseFloat(parts[0], 64)
+	g, err2 := strconv.ParseFloat(parts[1], 64)
+	b, err3 := strconv.ParseFloat(parts[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return fallback
+	}
+	return material.Color{R: r, G: g, B: b}
+}
+
+func parseMaterial(args map[string]string) *material.Material {
+	return &material.Material{
+		Color:               parseColor(args, "color", material.Color{R: 1, G: 1, B: 1}),
+		Reflectivity:        parseFloatOr(args, "reflectivity", 0),
+		Transparency:        parseFloatOr(args, "transparency", 0),
+		RefractiveIndex:     parseFloatOr(args, "refractive_index", 1),
+		AmbientCoefficient:  parseFloatOr(args, "ambient", 0.1),
+		DiffuseCoefficient:  parseFloatOr(args, "diffuse", 0.7),
+		SpecularCoefficient: parseFloatOr(args, "specular", 0.3),
+		Shininess:           parseFloatOr(args, "shininess", 32),
+		Emission:            parseColor(args, "emission", material.Color{}),
+	}
+}
+
+func resolveMaterial(materials map[string]*material.Material, args map[string]string) (*material.Material, error) {
+	name, ok := args["material"]
+	if !ok {
+		return nil, fmt.Errorf("missing %q argument", "material")
+	}
+	mat, ok := materials[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined material %q", name)
+	}
+	return mat, nil
+}
+
+func parseCamera(args map[string]string) (*geometry.Camera, error) {
+	lookFrom, err := parseVector(args, "lookfrom")
+	if err != nil {
+		return nil, err
+	}
+	lookAt, err := parseVector(args, "lookat")
+	if err != nil {
+		return nil, err
+	}
+	up := &geometry.Vector{X: 0, Y: 1, Z: 0}
+	if _, ok := args["up"]; ok {
+		if parsed, err := parseVector(args, "up"); err == nil {
+			up = parsed
+		}
+	}
+
+	vfov := parseFloatOr(args, "vfov", 45)
+
+	// A zero aperture degenerates NewCamera back to a pinhole, and focusing
+	// on lookAt by default is the least surprising behavior when a scene
+	// doesn't care about depth of field.
+	aperture := parseFloatOr(args, "aperture", 0)
+	focus := parseFloatOr(args, "focus", lookFrom.Subtract(lookAt).Length())
+
+	return geometry.NewCamera(lookFrom, lookAt, up, vfov, defaultAspectRatio, aperture, focus), nil
+}
+
+func parseLight(args map[string]string) (Light, error) {
+	pos, err := parseVector(args, "pos")
+	if err != nil {
+		return Light{}, err
+	}
+	color := parseColor(args, "color", material.Color{R: 1, G: 1, B: 1})
+	return Light{Position: pos, Color: color}, nil
+}
@@ -0,0 +1,109 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFile writes contents to a file named name inside a fresh temp dir and
+// returns its path.
+func writeFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
+
+// TestLoadSceneWiresMeshDirective checks that a "mesh" line loads the
+// referenced OBJ file and adds it to the scene as an object.
+func TestLoadSceneWiresMeshDirective(t *testing.T) {
+	objPath := writeFile(t, "triangle.obj", `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 3
+`)
+
+	scenePath := writeFile(t, "scene.txt", `
+material white color=1,1,1
+camera lookfrom=0,0,5 lookat=0,0,0 vfov=45
+mesh `+objPath+` material=white
+`)
+
+	s, err := LoadScene(scenePath)
+	if err != nil {
+		t.Fatalf("LoadScene returned error: %v", err)
+	}
+	if len(s.Objects) != 1 {
+		t.Fatalf("expected 1 object (the loaded mesh), got %d", len(s.Objects))
+	}
+}
+
+// TestLoadSceneRejectsUndefinedMaterial checks that a mesh directive
+// referencing an undeclared material fails rather than loading with a nil
+// material.
+func TestLoadSceneRejectsUndefinedMaterial(t *testing.T) {
+	objPath := writeFile(t, "triangle.obj", `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 3
+`)
+
+	scenePath := writeFile(t, "scene.txt", `
+camera lookfrom=0,0,5 lookat=0,0,0 vfov=45
+mesh `+objPath+` material=ivory
+`)
+
+	if _, err := LoadScene(scenePath); err == nil {
+		t.Fatalf("expected an error for a mesh directive referencing an undefined material")
+	}
+}
+
+// TestLoadSceneConfiguresDepthOfField checks that a camera directive's
+// aperture/focus arguments actually reach the constructed geometry.Camera
+// instead of always degenerating to a pinhole.
+func TestLoadSceneConfiguresDepthOfField(t *testing.T) {
+	scenePath := writeFile(t, "scene.txt", `
+camera lookfrom=0,0,5 lookat=0,0,0 vfov=45 aperture=0.5 focus=10
+`)
+
+	s, err := LoadScene(scenePath)
+	if err != nil {
+		t.Fatalf("LoadScene returned error: %v", err)
+	}
+
+	if s.Camera.Aperture != 0.5 {
+		t.Fatalf("expected aperture 0.5, got %v", s.Camera.Aperture)
+	}
+	if s.Camera.FocusDistance != 10 {
+		t.Fatalf("expected focus distance 10, got %v", s.Camera.FocusDistance)
+	}
+	if s.Camera.LensRadius != 0.25 {
+		t.Fatalf("expected lens radius 0.25, got %v", s.Camera.LensRadius)
+	}
+}
+
+// TestLoadSceneDefaultsToPinholeCamera checks that omitting aperture/focus
+// still produces a sensible pinhole camera focused on lookAt, matching the
+// pre-DOF-parsing behavior.
+func TestLoadSceneDefaultsToPinholeCamera(t *testing.T) {
+	scenePath := writeFile(t, "scene.txt", `
+camera lookfrom=0,0,5 lookat=0,0,0 vfov=45
+`)
+
+	s, err := LoadScene(scenePath)
+	if err != nil {
+		t.Fatalf("LoadScene returned error: %v", err)
+	}
+
+	if s.Camera.Aperture != 0 {
+		t.Fatalf("expected a pinhole (aperture 0) by default, got %v", s.Camera.Aperture)
+	}
+	if s.Camera.FocusDistance != 5 {
+		t.Fatalf("expected focus distance to default to the lookfrom-lookat distance (5), got %v", s.Camera.FocusDistance)
+	}
+}
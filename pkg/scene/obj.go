@@ -0,0 +1,181 @@
+package scene
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gotracing/pkg/geometry"
+	"gotracing/pkg/material"
+)
+
+// LoadOBJ parses a Wavefront OBJ file at path into a single geometry.Mesh,
+// assigning mat to every face. It understands "v", "vn", "vt", and "f"
+// directives, including the "a/b/c" vertex/uv/normal form and negative
+// (relative-to-end) indices. A scene file wires a mesh in with a line like:
+//
+//	mesh bunny.obj material=ivory
+func LoadOBJ(path string, mat *material.Material) (*geometry.Mesh, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening OBJ file: %w", err)
+	}
+	defer file.Close()
+
+	var vertices []*geometry.Vector
+	var normals []*geometry.Vector
+	var uvs []geometry.Vector2
+	var faces []geometry.MeshFace
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			v, err := parseOBJVector(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			vertices = append(vertices, v)
+		case "vn":
+			n, err := parseOBJVector(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			normals = append(normals, n)
+		case "vt":
+			uv, err := parseOBJUV(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			uvs = append(uvs, uv)
+		case "f":
+			face, err := parseOBJFace(fields[1:], len(vertices), len(normals), len(uvs))
+			if err != nil {
+				return nil, err
+			}
+			faces = append(faces, face...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading OBJ file: %w", err)
+	}
+
+	return geometry.NewMesh(vertices, normals, uvs, faces, mat), nil
+}
+
+func parseOBJVector(fields []string) (*geometry.Vector, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("expected 3 components, got %d", len(fields))
+	}
+
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return nil, err
+	}
+	z, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &geometry.Vector{X: x, Y: y, Z: z}, nil
+}
+
+func parseOBJUV(fields []string) (geometry.Vector2, error) {
+	if len(fields) < 2 {
+		return geometry.Vector2{}, fmt.Errorf("expected at least 2 components, got %d", len(fields))
+	}
+
+	u, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return geometry.Vector2{}, err
+	}
+	v, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return geometry.Vector2{}, err
+	}
+
+	return geometry.Vector2{U: u, V: v}, nil
+}
+
+// objCorner is one vertex/uv/normal index triple within a face directive.
+type objCorner struct {
+	vertex, normal, uv int
+}
+
+// parseOBJFace parses a polygon's indices and triangle-fans it, since OBJ
+// faces aren't necessarily triangles.
+func parseOBJFace(fields []string, vertexCount, normalCount, uvCount int) ([]geometry.MeshFace, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("face needs at least 3 vertices, got %d", len(fields))
+	}
+
+	corners := make([]objCorner, len(fields))
+	for i, field := range fields {
+		parts := strings.Split(field, "/")
+
+		vertex, err := parseOBJIndex(parts[0], vertexCount)
+		if err != nil {
+			return nil, err
+		}
+
+		uv := -1
+		if len(parts) >= 2 && parts[1] != "" {
+			if uv, err = parseOBJIndex(parts[1], uvCount); err != nil {
+				return nil, err
+			}
+		}
+
+		normal := -1
+		if len(parts) >= 3 && parts[2] != "" {
+			if normal, err = parseOBJIndex(parts[2], normalCount); err != nil {
+				return nil, err
+			}
+		}
+
+		corners[i] = objCorner{vertex, normal, uv}
+	}
+
+	faces := make([]geometry.MeshFace, 0, len(corners)-2)
+	for i := 1; i < len(corners)-1; i++ {
+		faces = append(faces, geometry.MeshFace{
+			V0: corners[0].vertex, V1: corners[i].vertex, V2: corners[i+1].vertex,
+			N0: corners[0].normal, N1: corners[i].normal, N2: corners[i+1].normal,
+			T0: corners[0].uv, T1: corners[i].uv, T2: corners[i+1].uv,
+		})
+	}
+	return faces, nil
+}
+
+// parseOBJIndex resolves a 1-based OBJ index, supporting the negative
+// (relative-to-end) form, and bounds-checks the result against count so a
+// malformed face line fails here with a clear error instead of panicking
+// deep inside Mesh.Intersect at render time.
+func parseOBJIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %q: %w", s, err)
+	}
+
+	var resolved int
+	if i < 0 {
+		resolved = count + i
+	} else {
+		resolved = i - 1
+	}
+
+	if resolved < 0 || resolved >= count {
+		return 0, fmt.Errorf("index %q resolves to %d, out of range [0, %d)", s, resolved, count)
+	}
+	return resolved, nil
+}
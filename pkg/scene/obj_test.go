@@ -0,0 +1,107 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotracing/pkg/material"
+)
+
+// writeOBJ writes contents to a temporary .obj file and returns its path.
+func writeOBJ(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mesh.obj")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing test OBJ file: %v", err)
+	}
+	return path
+}
+
+// TestLoadOBJParsesTriangle checks a minimal one-face OBJ file with vertex
+// normals and texture coordinates loads into a single-face Mesh with the
+// expected vertex and normal indices resolved.
+func TestLoadOBJParsesTriangle(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vn 0 0 1
+vt 0 0
+vt 1 0
+vt 0 1
+f 1/1/1 2/2/1 3/3/1
+`)
+
+	mesh, err := LoadOBJ(path, &material.Material{})
+	if err != nil {
+		t.Fatalf("LoadOBJ returned error: %v", err)
+	}
+
+	if len(mesh.Vertices) != 3 {
+		t.Fatalf("expected 3 vertices, got %d", len(mesh.Vertices))
+	}
+	if len(mesh.Faces) != 1 {
+		t.Fatalf("expected 1 face, got %d", len(mesh.Faces))
+	}
+
+	face := mesh.Faces[0]
+	if face.V0 != 0 || face.V1 != 1 || face.V2 != 2 {
+		t.Fatalf("expected vertex indices (0,1,2), got (%d,%d,%d)", face.V0, face.V1, face.V2)
+	}
+	if face.N0 != 0 || face.N1 != 0 || face.N2 != 0 {
+		t.Fatalf("expected normal index 0 on every corner, got (%d,%d,%d)", face.N0, face.N1, face.N2)
+	}
+}
+
+// TestLoadOBJTriangleFansQuad checks that a 4-vertex face directive is
+// triangle-fanned into two faces.
+func TestLoadOBJTriangleFansQuad(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3 4
+`)
+
+	mesh, err := LoadOBJ(path, &material.Material{})
+	if err != nil {
+		t.Fatalf("LoadOBJ returned error: %v", err)
+	}
+	if len(mesh.Faces) != 2 {
+		t.Fatalf("expected a quad to fan into 2 faces, got %d", len(mesh.Faces))
+	}
+}
+
+// TestLoadOBJRejectsOutOfRangeIndex checks that a face referencing a vertex
+// index beyond the file's vertex count is a parse-time error rather than a
+// later panic in Mesh.Intersect.
+func TestLoadOBJRejectsOutOfRangeIndex(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 5
+`)
+
+	if _, err := LoadOBJ(path, &material.Material{}); err == nil {
+		t.Fatalf("expected an error for a face referencing out-of-range vertex index 5")
+	}
+}
+
+// TestLoadOBJRejectsOutOfRangeNegativeIndex checks that a negative
+// (relative-to-end) index that resolves before the start of the array is
+// also rejected.
+func TestLoadOBJRejectsOutOfRangeNegativeIndex(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 -5
+`)
+
+	if _, err := LoadOBJ(path, &material.Material{}); err == nil {
+		t.Fatalf("expected an error for a face referencing out-of-range negative index -5")
+	}
+}
@@ -7,34 +7,77 @@ import (
 	"gotracing/pkg/material"
 )
 
+// Light is a simple point light used by the Whitted-style direct lighting
+// term in pkg/raytracer. It lives here rather than in pkg/material so that
+// package can stay a leaf with no dependency on pkg/geometry.
+type Light struct {
+	Position *geometry.Vector
+	Color    material.Color
+}
+
 type Scene struct {
 	Objects []geometry.Object
-	Lights  []material.Light
+	Lights  []Light
 	Camera  *geometry.Camera
+	// Emitters holds every object whose material has a non-zero Emission,
+	// so the path tracer can sample them directly via next-event estimation
+	// instead of waiting for a path to hit them by chance.
+	Emitters []geometry.Object
+
+	bvh *geometry.BVH
 }
 
 func NewScene(camera *geometry.Camera) *Scene {
 	return &Scene{
-		Objects: make([]geometry.Object, 0),
-		Lights:  make([]material.Light, 0),
-		Camera:  camera,
+		Objects:  make([]geometry.Object, 0),
+		Lights:   make([]Light, 0),
+		Camera:   camera,
+		Emitters: make([]geometry.Object, 0),
 	}
 }
 
 func (s *Scene) AddObject(object geometry.Object) {
 	s.Objects = append(s.Objects, object)
+
+	if mat := object.Material(); mat != nil {
+		if mat.Emission.R > 0 || mat.Emission.G > 0 || mat.Emission.B > 0 {
+			s.Emitters = append(s.Emitters, object)
+		}
+	}
 }
 
-func (s *Scene) AddLight(light material.Light) {
+func (s *Scene) AddLight(light Light) {
 	s.Lights = append(s.Lights, light)
 }
 
+// Build constructs a bounding volume hierarchy over every object currently
+// in the scene. Call it once after all objects have been added and before
+// rendering; FindClosestIntersection falls back to a linear scan if it
+// hasn't been called.
+func (s *Scene) Build() {
+	if len(s.Objects) == 0 {
+		s.bvh = nil
+		return
+	}
+	s.bvh = geometry.BuildBVH(s.Objects)
+}
+
 func (s *Scene) FindClosestIntersection(ray *geometry.Ray) (*geometry.Hit, *geometry.Object) {
+	if s.bvh != nil {
+		hit := s.bvh.Intersect(ray)
+		if hit == nil {
+			return nil, nil
+		}
+		object := hit.Object
+		return hit, &object
+	}
+
 	var closestHit *geometry.Hit
 	var closestObject *geometry.Object
 	closestDistance := math.Inf(1)
 
 	for _, object := range s.Objects {
+		object := object // avoid aliasing the shared loop variable across iterations
 		hit := object.Intersect(ray)
 		if hit != nil && hit.T < closestDistance {
 			closestHit = hit